@@ -8,9 +8,20 @@ This is a Go file with proper SPDX license declaration.
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/eric2023/spdx-checker/scanners"
+	"github.com/eric2023/spdx-checker/selector"
 )
 
 // Config represents application configuration
@@ -18,28 +29,129 @@ type Config struct {
 	Port        int
 	DatabaseURL string
 	Debug       bool
+
+	// Include and Exclude are space-separated glob pattern strings
+	// consulted by the file walker before a file is opened for scanning.
+	// See the selector package for the pattern syntax.
+	Include string
+	Exclude string
+
+	// ExternalScanner names a built-in scanners.Scanner ("scancode",
+	// "licensee", "askalono") to cross-check in-source SPDX headers
+	// against, or "" to disable external scanning.
+	ExternalScanner string
+	ScannerTimeout  time.Duration
+}
+
+// validateConfig checks config for the mistakes that would otherwise only
+// surface as a confusing failure later (an unroutable listener, a matcher
+// that silently never matches, a missing database in production).
+func validateConfig(config Config) error {
+	if config.Port <= 0 {
+		return fmt.Errorf("config: port must be positive, got %d", config.Port)
+	}
+	if config.DatabaseURL == "" && !config.Debug {
+		return fmt.Errorf("config: database url is required outside debug mode")
+	}
+	if config.Include != "" {
+		if _, err := selector.Parse(config.Include); err != nil {
+			return fmt.Errorf("config: include pattern: %w", err)
+		}
+	}
+	if config.Exclude != "" {
+		if _, err := selector.Parse(config.Exclude); err != nil {
+			return fmt.Errorf("config: exclude pattern: %w", err)
+		}
+	}
+	return nil
 }
 
 // Server represents the HTTP server
 type Server struct {
 	config Config
 	logger *log.Logger
+
+	mux     *http.ServeMux
+	httpSrv *http.Server
+	metrics *serverMetrics
+
+	include    selector.Matcher
+	exclude    selector.Matcher
+	extScanner scanners.Scanner
+
+	mu      sync.Mutex
+	reports map[string]*ScanReport
 }
 
-// NewServer creates a new server instance
-func NewServer(config Config, logger *log.Logger) *Server {
-	return &Server{
-		config: config,
-		logger: logger,
+// NewServer creates a new server instance, wiring up its own
+// *http.ServeMux and Prometheus registry so it can be exercised in tests
+// without touching the global http.DefaultServeMux or registry. It returns
+// an error if config fails validateConfig.
+func NewServer(config Config, logger *log.Logger) (*Server, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	registry := prometheus.NewRegistry()
+	s := &Server{
+		config:  config,
+		logger:  logger,
+		mux:     http.NewServeMux(),
+		metrics: newServerMetrics(registry),
+		reports: make(map[string]*ScanReport),
+	}
+	if config.Include != "" {
+		s.include, _ = selector.Parse(config.Include)
 	}
+	if config.Exclude != "" {
+		s.exclude, _ = selector.Parse(config.Exclude)
+	}
+	extScanner, err := scanners.New(config.ExternalScanner, scanners.Config{Timeout: config.ScannerTimeout})
+	if err != nil {
+		return nil, err
+	}
+	s.extScanner = extScanner
+	s.routes(registry)
+	return s, nil
+}
+
+// routes registers the server's HTTP API on its mux.
+func (s *Server) routes(registry *prometheus.Registry) {
+	s.mux.HandleFunc("/", s.homeHandler)
+	s.mux.HandleFunc("/scan", s.scanHandler)
+	s.mux.HandleFunc("/report/", s.reportHandler)
+	s.mux.HandleFunc("/healthz", s.healthzHandler)
+	s.mux.Handle("/metrics", promHandler(registry))
 }
 
-// Start starts the HTTP server
-func (s *Server) Start() error {
-	http.HandleFunc("/", s.homeHandler)
-	addr := fmt.Sprintf(":%d", s.config.Port)
-	s.logger.Printf("Starting server on %s", addr)
-	return http.ListenAndServe(addr, nil)
+// Start binds addr and serves until Shutdown is called or Serve returns a
+// fatal error. It returns once the listener is closed.
+func (s *Server) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+	return s.Serve(ln)
+}
+
+// Serve runs the server on a caller-supplied listener. Passing a listener
+// bound to ":0" lets tests discover the chosen port via ln.Addr().
+func (s *Server) Serve(ln net.Listener) error {
+	s.httpSrv = &http.Server{Handler: s.mux}
+	s.logger.Printf("Starting server on %s", ln.Addr())
+	if err := s.httpSrv.Serve(ln); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the server, waiting for in-flight requests to
+// finish or ctx to expire.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.httpSrv == nil {
+		return nil
+	}
+	return s.httpSrv.Shutdown(ctx)
 }
 
 func (s *Server) homeHandler(w http.ResponseWriter, r *http.Request) {
@@ -57,9 +169,23 @@ func main() {
 	}
 
 	logger := log.New(os.Stdout, "[SPDX-Scanner] ", log.LstdFlags)
-	server := NewServer(config, logger)
+	server, err := NewServer(config, logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Printf("shutdown error: %v", err)
+		}
+	}()
 
-	if err := server.Start(); err != nil {
+	if err := server.Start(fmt.Sprintf(":%d", config.Port)); err != nil {
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
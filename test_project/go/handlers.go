@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/eric2023/spdx-checker/sbom"
+	"github.com/eric2023/spdx-checker/scanners"
+	"github.com/eric2023/spdx-checker/tvsaver"
+)
+
+// spdxTagPattern matches an "SPDX-License-Identifier: <expr>" header, as
+// found in a comment block at the top of a source file.
+var spdxTagPattern = regexp.MustCompile(`SPDX-License-Identifier:\s*(\S+)`)
+
+// ScanResult is the outcome of checking a single file for an SPDX header.
+type ScanResult struct {
+	File   string `json:"file"`
+	SPDXID string `json:"spdxId,omitempty"`
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ScanReport is the full, addressable result of one /scan request.
+type ScanReport struct {
+	ID                string               `json:"id"`
+	Path              string               `json:"path"`
+	StartedAt         time.Time            `json:"startedAt"`
+	Results           []ScanResult         `json:"results"`
+	ExternalAgreement []scanners.Agreement `json:"externalAgreement,omitempty"`
+	Discrepancies     []sbom.Discrepancy   `json:"discrepancies,omitempty"`
+}
+
+// scanRequest is the body accepted by POST /scan.
+type scanRequest struct {
+	Path      string   `json:"path"`
+	Recursive bool     `json:"recursive"`
+	Exclude   []string `json:"exclude"`
+
+	// SBOM, when set, names an existing SPDX document to load and
+	// cross-check the scan's in-source SPDX headers against (see
+	// sbom.CrossCheck). SBOMFormat selects how to parse it: "json"
+	// (default), "rdf", or "tag-value".
+	SBOM       string `json:"sbom"`
+	SBOMFormat string `json:"sbomFormat"`
+}
+
+// parseSBOMFormat maps a scanRequest.SBOMFormat string to an sbom.Format,
+// defaulting to FormatJSON when unset.
+func parseSBOMFormat(format string) (sbom.Format, error) {
+	switch format {
+	case "", "json":
+		return sbom.FormatJSON, nil
+	case "rdf":
+		return sbom.FormatRDF, nil
+	case "tag-value", "tv":
+		return sbom.FormatTagValue, nil
+	default:
+		return 0, fmt.Errorf("unknown sbomFormat %q", format)
+	}
+}
+
+var nextReportID int64
+
+// scanHandler walks the requested path, streaming one NDJSON-encoded
+// ScanResult per file as it is checked, and stores the completed report
+// so it can be retrieved later from GET /report/{id}.
+func (s *Server) scanHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req scanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Path == "" {
+		http.Error(w, "path is required", http.StatusBadRequest)
+		return
+	}
+	sbomFormat, err := parseSBOMFormat(req.SBOMFormat)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := strconv.FormatInt(atomic.AddInt64(&nextReportID, 1), 10)
+	report := &ScanReport{ID: id, Path: req.Path, StartedAt: time.Now()}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	start := time.Now()
+	err = walkSource(req.Path, req.Recursive, req.Exclude, func(path string) bool {
+		if s.exclude != nil && s.exclude.Match(path) {
+			return false
+		}
+		if s.include != nil && !s.include.Match(path) {
+			return false
+		}
+		return true
+	}, func(path string) error {
+		result := checkFile(path)
+		report.Results = append(report.Results, result)
+		s.recordResult(result)
+
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if s.extScanner != nil {
+		report.ExternalAgreement = s.crossCheckExternal(r.Context(), report)
+	}
+	if req.SBOM != "" {
+		discrepancies, cerr := s.crossCheckSBOM(req.SBOM, sbomFormat, report)
+		if cerr != nil {
+			s.logger.Printf("scan %s: sbom cross-check: %v", id, cerr)
+		}
+		report.Discrepancies = discrepancies
+	}
+	s.metrics.scanDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		s.logger.Printf("scan %s: %v", id, err)
+	}
+
+	s.mu.Lock()
+	s.reports[id] = report
+	s.mu.Unlock()
+}
+
+// crossCheckExternal runs the server's configured external scanner over the
+// just-scanned path and reports where its detections agree or disagree
+// with the in-source SPDX headers already collected in report.
+func (s *Server) crossCheckExternal(ctx context.Context, report *ScanReport) []scanners.Agreement {
+	detected, err := s.extScanner.Scan(ctx, report.Path)
+	if err != nil {
+		s.logger.Printf("scan %s: external scanner: %v", report.ID, err)
+		return nil
+	}
+
+	inSource := make(map[string]string, len(report.Results))
+	for _, r := range report.Results {
+		if r.SPDXID != "" {
+			inSource[r.File] = r.SPDXID
+		}
+	}
+	return scanners.Merge(inSource, detected)
+}
+
+// crossCheckSBOM loads the SPDX document at sbomPath and cross-checks the
+// in-source SPDX headers already collected in report against it, per
+// sbom.CrossCheck.
+func (s *Server) crossCheckSBOM(sbomPath string, format sbom.Format, report *ScanReport) ([]sbom.Discrepancy, error) {
+	f, err := os.Open(sbomPath)
+	if err != nil {
+		return nil, fmt.Errorf("open sbom: %w", err)
+	}
+	defer f.Close()
+
+	doc, err := sbom.Load(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("load sbom: %w", err)
+	}
+
+	findings := make([]sbom.SourceFinding, 0, len(report.Results))
+	for _, r := range report.Results {
+		if r.SPDXID == "" {
+			continue
+		}
+		path := r.File
+		if rel, err := filepath.Rel(report.Path, r.File); err == nil && rel != "." {
+			path = "./" + filepath.ToSlash(rel)
+		}
+		findings = append(findings, sbom.SourceFinding{Path: path, SPDXID: r.SPDXID})
+	}
+	return sbom.CrossCheck(doc, findings), nil
+}
+
+// recordResult updates the scan counters for a single file's result.
+func (s *Server) recordResult(result ScanResult) {
+	s.metrics.filesScanned.Inc()
+	switch result.Reason {
+	case "missing-header":
+		s.metrics.missingSPDXHeaders.Inc()
+	case sbom.DeprecatedIdentifier.String():
+		s.metrics.deprecatedIdentifiers.Inc()
+	case sbom.UnknownIdentifier.String():
+		s.metrics.unknownIdentifiers.Inc()
+	}
+}
+
+// reportHandler serves a previously completed scan report as JSON, or as
+// an SPDX 2.3 tag-value document when format=spdx-tv is requested.
+func (s *Server) reportHandler(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/report/")
+	if id == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	report, ok := s.reports[id]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown report id", http.StatusNotFound)
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "spdx-tv":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := tvsaver.NewWriter(w).Write(reportToDocument(report)); err != nil {
+			s.logger.Printf("report %s: write spdx-tv: %v", id, err)
+		}
+	case "spdx-json":
+		w.Header().Set("Content-Type", "application/json")
+		if err := sbom.NewWriter(w).WriteJSON(reportToDocument(report)); err != nil {
+			s.logger.Printf("report %s: write spdx-json: %v", id, err)
+		}
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(report)
+	}
+}
+
+// reportToDocument translates a ScanReport into the normalized sbom.Document
+// shape so it can be rendered by sbom.Writer or tvsaver.Writer.
+func reportToDocument(report *ScanReport) *sbom.Document {
+	const packageSPDXID = "SPDXRef-Package-scan"
+	doc := &sbom.Document{
+		DocumentName:      "scan-" + report.ID,
+		DocumentNamespace: "https://spdx.org/spdxdocs/scan-" + report.ID,
+		Packages: []sbom.Package{{
+			Name:             report.Path,
+			SPDXID:           packageSPDXID,
+			DownloadLocation: "NOASSERTION",
+		}},
+	}
+	doc.Relationships = append(doc.Relationships, sbom.Relationship{
+		SPDXElementID:      "SPDXRef-DOCUMENT",
+		RelationshipType:   "DESCRIBES",
+		RelatedSPDXElement: packageSPDXID,
+	})
+	for i, result := range report.Results {
+		file := sbom.File{
+			Name:   result.File,
+			SPDXID: fmt.Sprintf("SPDXRef-Item-%d", i+1),
+		}
+		if result.SPDXID != "" {
+			file.LicenseInfoInFile = []string{result.SPDXID}
+			file.LicenseConcluded = result.SPDXID
+		}
+		doc.Files = append(doc.Files, file)
+		doc.Relationships = append(doc.Relationships, sbom.Relationship{
+			SPDXElementID:      packageSPDXID,
+			RelationshipType:   "CONTAINS",
+			RelatedSPDXElement: file.SPDXID,
+		})
+	}
+	return doc
+}
+
+func (s *Server) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("ok"))
+}
+
+// walkSource visits every regular file under root, calling visit with its
+// path unless it is excluded by one of the request-level exclude glob
+// patterns or rejected by the server's configured selector (keep).
+func walkSource(root string, recursive bool, exclude []string, keep func(path string) bool, visit func(path string) error) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if matchesAny(exclude, path) {
+			return nil
+		}
+		if keep != nil && !keep(path) {
+			return nil
+		}
+		return visit(path)
+	})
+}
+
+func matchesAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFile reads the first few lines of path looking for an
+// SPDX-License-Identifier header and classifies what it finds.
+func checkFile(path string) ScanResult {
+	f, err := os.Open(path)
+	if err != nil {
+		return ScanResult{File: path, Valid: false, Reason: err.Error()}
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < 20 && scanner.Scan(); i++ {
+		m := spdxTagPattern.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		id := m[1]
+		if reason, issue := sbom.CheckIdentifier(id); issue {
+			return ScanResult{File: path, SPDXID: id, Valid: false, Reason: reason.String()}
+		}
+		return ScanResult{File: path, SPDXID: id, Valid: true}
+	}
+	return ScanResult{File: path, Valid: false, Reason: "missing-header"}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// serverMetrics holds the Prometheus instrumentation exported on /metrics.
+type serverMetrics struct {
+	filesScanned          prometheus.Counter
+	missingSPDXHeaders    prometheus.Counter
+	deprecatedIdentifiers prometheus.Counter
+	unknownIdentifiers    prometheus.Counter
+	scanDuration          prometheus.Histogram
+}
+
+// newServerMetrics registers the scanner's metrics against registry and
+// returns the handle used to update them.
+func newServerMetrics(registry *prometheus.Registry) *serverMetrics {
+	factory := promauto.With(registry)
+	return &serverMetrics{
+		filesScanned: factory.NewCounter(prometheus.CounterOpts{
+			Name: "spdx_checker_files_scanned_total",
+			Help: "Total number of source files scanned for SPDX headers.",
+		}),
+		missingSPDXHeaders: factory.NewCounter(prometheus.CounterOpts{
+			Name: "spdx_checker_missing_headers_total",
+			Help: "Total number of files scanned with no SPDX-License-Identifier header.",
+		}),
+		deprecatedIdentifiers: factory.NewCounter(prometheus.CounterOpts{
+			Name: "spdx_checker_deprecated_identifiers_total",
+			Help: "Total number of deprecated SPDX identifiers found.",
+		}),
+		unknownIdentifiers: factory.NewCounter(prometheus.CounterOpts{
+			Name: "spdx_checker_unknown_identifiers_total",
+			Help: "Total number of unrecognized SPDX identifiers found.",
+		}),
+		scanDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "spdx_checker_scan_duration_seconds",
+			Help:    "Wall-clock duration of a full /scan request.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+}
+
+// promHandler returns the /metrics handler for registry.
+func promHandler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
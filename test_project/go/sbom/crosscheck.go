@@ -0,0 +1,149 @@
+package sbom
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SourceFinding is the SPDX-License-Identifier header discovered by the
+// scanner for a single source file, keyed by its path relative to the
+// project root (matching the "./path" form SPDX documents use for
+// FileName).
+type SourceFinding struct {
+	Path   string
+	SPDXID string // the SPDX expression found in the file header, e.g. "MIT"
+}
+
+// Discrepancy describes a single mismatch between the source tree and an
+// SPDX document.
+type Discrepancy struct {
+	Path   string
+	Reason DiscrepancyReason
+	Detail string
+}
+
+// DiscrepancyReason classifies a Discrepancy.
+type DiscrepancyReason int
+
+const (
+	// MissingFromSBOM means the file was found in the tree but the SBOM has
+	// no hasFile/describes relationship naming it.
+	MissingFromSBOM DiscrepancyReason = iota
+	// LicenseMismatch means the SBOM's licenseInfoInFile disagrees with the
+	// in-source SPDX-License-Identifier tag.
+	LicenseMismatch
+	// UnknownIdentifier means the SPDX identifier is not a recognized
+	// license short identifier.
+	UnknownIdentifier
+	// DeprecatedIdentifier means the SPDX identifier has been deprecated by
+	// the SPDX license list.
+	DeprecatedIdentifier
+)
+
+func (r DiscrepancyReason) String() string {
+	switch r {
+	case MissingFromSBOM:
+		return "missing-from-sbom"
+	case LicenseMismatch:
+		return "license-mismatch"
+	case UnknownIdentifier:
+		return "unknown-identifier"
+	case DeprecatedIdentifier:
+		return "deprecated-identifier"
+	default:
+		return "unknown"
+	}
+}
+
+// CrossCheck compares the SPDX headers the scanner found in the source tree
+// against an existing SPDX document, returning every discrepancy found. The
+// returned slice is ordered by source-file path for stable output.
+func CrossCheck(doc *Document, findings []SourceFinding) []Discrepancy {
+	var discrepancies []Discrepancy
+
+	for _, f := range findings {
+		if reason, ok := CheckIdentifier(f.SPDXID); ok {
+			discrepancies = append(discrepancies, Discrepancy{
+				Path:   f.Path,
+				Reason: reason,
+				Detail: f.SPDXID,
+			})
+		}
+
+		sbomFile, found := doc.FileByName(f.Path)
+		if !found {
+			discrepancies = append(discrepancies, Discrepancy{
+				Path:   f.Path,
+				Reason: MissingFromSBOM,
+				Detail: fmt.Sprintf("no file entry for %q in SBOM", f.Path),
+			})
+			continue
+		}
+		if !doc.DescribesFile(sbomFile.SPDXID) {
+			discrepancies = append(discrepancies, Discrepancy{
+				Path:   f.Path,
+				Reason: MissingFromSBOM,
+				Detail: fmt.Sprintf("file entry %q exists but no hasFile/describes relationship names %s", f.Path, sbomFile.SPDXID),
+			})
+			continue
+		}
+
+		if !licenseListContains(sbomFile.LicenseInfoInFile, f.SPDXID) {
+			discrepancies = append(discrepancies, Discrepancy{
+				Path:   f.Path,
+				Reason: LicenseMismatch,
+				Detail: fmt.Sprintf("source header %q not in SBOM licenseInfoInFile %v", f.SPDXID, sbomFile.LicenseInfoInFile),
+			})
+		}
+	}
+
+	sort.SliceStable(discrepancies, func(i, j int) bool {
+		return discrepancies[i].Path < discrepancies[j].Path
+	})
+	return discrepancies
+}
+
+func licenseListContains(list []string, id string) bool {
+	for _, l := range list {
+		if l == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIdentifier reports whether id is unknown or deprecated according to
+// the bundled (non-exhaustive) SPDX license list snapshot.
+func CheckIdentifier(id string) (DiscrepancyReason, bool) {
+	if _, deprecated := deprecatedIdentifiers[id]; deprecated {
+		return DeprecatedIdentifier, true
+	}
+	if _, known := knownIdentifiers[id]; !known {
+		return UnknownIdentifier, true
+	}
+	return 0, false
+}
+
+// knownIdentifiers is a snapshot of common current SPDX license short
+// identifiers. It is not exhaustive; callers needing full coverage of the
+// SPDX license list should replace this with a generated table.
+var knownIdentifiers = map[string]bool{
+	"MIT": true, "Apache-2.0": true, "BSD-2-Clause": true, "BSD-3-Clause": true,
+	"GPL-2.0-only": true, "GPL-2.0-or-later": true, "GPL-3.0-only": true, "GPL-3.0-or-later": true,
+	"LGPL-2.1-only": true, "LGPL-2.1-or-later": true, "MPL-2.0": true, "ISC": true,
+	"Unlicense": true, "CC0-1.0": true, "AGPL-3.0-only": true, "AGPL-3.0-or-later": true,
+}
+
+// deprecatedIdentifiers maps deprecated SPDX short identifiers to their
+// current replacement, per the SPDX license list's deprecation notices.
+var deprecatedIdentifiers = map[string]string{
+	"GPL-2.0":     "GPL-2.0-only",
+	"GPL-2.0+":    "GPL-2.0-or-later",
+	"GPL-3.0":     "GPL-3.0-only",
+	"GPL-3.0+":    "GPL-3.0-or-later",
+	"LGPL-2.1":    "LGPL-2.1-only",
+	"LGPL-2.1+":   "LGPL-2.1-or-later",
+	"AGPL-3.0":    "AGPL-3.0-only",
+	"AGPL-3.0+":   "AGPL-3.0-or-later",
+	"bzip2-1.0.5": "bzip2-1.0.6",
+}
@@ -0,0 +1,81 @@
+package sbom
+
+import "testing"
+
+func crossCheckTestDoc() *Document {
+	return &Document{
+		Files: []File{
+			{Name: "./known.go", SPDXID: "SPDXRef-File-known", LicenseInfoInFile: []string{"MIT"}},
+			{Name: "./orphan.go", SPDXID: "SPDXRef-File-orphan", LicenseInfoInFile: []string{"MIT"}},
+		},
+		Relationships: []Relationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-File-known"},
+		},
+	}
+}
+
+func TestCrossCheck(t *testing.T) {
+	doc := crossCheckTestDoc()
+
+	tests := []struct {
+		name     string
+		finding  SourceFinding
+		wantReas []DiscrepancyReason
+	}{
+		{
+			name:    "clean match",
+			finding: SourceFinding{Path: "./known.go", SPDXID: "MIT"},
+		},
+		{
+			name:     "missing file entry",
+			finding:  SourceFinding{Path: "./missing.go", SPDXID: "MIT"},
+			wantReas: []DiscrepancyReason{MissingFromSBOM},
+		},
+		{
+			name:     "file entry with no describes/contains relationship",
+			finding:  SourceFinding{Path: "./orphan.go", SPDXID: "MIT"},
+			wantReas: []DiscrepancyReason{MissingFromSBOM},
+		},
+		{
+			name:     "license mismatch",
+			finding:  SourceFinding{Path: "./known.go", SPDXID: "Apache-2.0"},
+			wantReas: []DiscrepancyReason{LicenseMismatch},
+		},
+		{
+			name:     "unknown identifier",
+			finding:  SourceFinding{Path: "./known.go", SPDXID: "Not-A-Real-License"},
+			wantReas: []DiscrepancyReason{UnknownIdentifier, LicenseMismatch},
+		},
+		{
+			name:     "deprecated identifier",
+			finding:  SourceFinding{Path: "./known.go", SPDXID: "GPL-2.0"},
+			wantReas: []DiscrepancyReason{DeprecatedIdentifier, LicenseMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CrossCheck(doc, []SourceFinding{tt.finding})
+			if len(got) != len(tt.wantReas) {
+				t.Fatalf("CrossCheck() = %+v, want %d discrepancies", got, len(tt.wantReas))
+			}
+			for i, want := range tt.wantReas {
+				if got[i].Reason != want {
+					t.Errorf("discrepancy[%d].Reason = %v, want %v", i, got[i].Reason, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckIdentifier(t *testing.T) {
+	if _, bad := CheckIdentifier("MIT"); bad {
+		t.Error("CheckIdentifier(MIT) flagged as bad")
+	}
+	if reason, bad := CheckIdentifier("GPL-2.0"); !bad || reason != DeprecatedIdentifier {
+		t.Errorf("CheckIdentifier(GPL-2.0) = %v, %v, want DeprecatedIdentifier, true", reason, bad)
+	}
+	if reason, bad := CheckIdentifier("Not-A-Real-License"); !bad || reason != UnknownIdentifier {
+		t.Errorf("CheckIdentifier(Not-A-Real-License) = %v, %v, want UnknownIdentifier, true", reason, bad)
+	}
+}
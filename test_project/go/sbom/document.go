@@ -0,0 +1,85 @@
+// Package sbom provides a normalized in-memory representation of an SPDX
+// bill-of-materials document and the means to load one from disk (JSON, RDF,
+// or tag-value) and cross-check it against SPDX-License-Identifier headers
+// discovered in source files.
+package sbom
+
+// Format identifies the on-disk encoding of an SPDX document.
+type Format int
+
+const (
+	// FormatJSON is the SPDX 2.x JSON encoding.
+	FormatJSON Format = iota
+	// FormatRDF is the SPDX RDF/XML encoding.
+	FormatRDF
+	// FormatTagValue is the classic "Key: value" SPDX encoding.
+	FormatTagValue
+)
+
+// Document is a normalized view of an SPDX 2.2/2.3 document, independent of
+// the format it was loaded from.
+type Document struct {
+	SPDXVersion       string
+	DataLicense       string
+	DocumentName      string
+	DocumentNamespace string
+
+	Packages      []Package
+	Files         []File
+	Relationships []Relationship
+}
+
+// Package corresponds to an SPDX Package element.
+type Package struct {
+	Name                    string
+	SPDXID                  string
+	VersionInfo             string
+	DownloadLocation        string
+	PackageLicenseDeclared  string
+	PackageLicenseConcluded string
+	CopyrightText           string
+}
+
+// File corresponds to an SPDX File element.
+type File struct {
+	Name              string
+	SPDXID            string
+	LicenseInfoInFile []string
+	LicenseConcluded  string
+	CopyrightText     string
+	ChecksumSHA1      string
+}
+
+// Relationship corresponds to an SPDX Relationship element, e.g.
+// "SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-foo".
+type Relationship struct {
+	SPDXElementID      string
+	RelatedSPDXElement string
+	RelationshipType   string
+}
+
+// FileByName returns the File with the given name (as recorded in the SBOM,
+// typically "./path/to/file"), or false if it is not present.
+func (d *Document) FileByName(name string) (File, bool) {
+	for _, f := range d.Files {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return File{}, false
+}
+
+// DescribesFile reports whether the document has a DESCRIBES or CONTAINS
+// relationship (directly or via hasFile) pointing at the given file SPDX ID.
+func (d *Document) DescribesFile(fileSPDXID string) bool {
+	for _, rel := range d.Relationships {
+		if rel.RelatedSPDXElement != fileSPDXID {
+			continue
+		}
+		switch rel.RelationshipType {
+		case "DESCRIBES", "CONTAINS", "hasFile":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,392 @@
+package sbom
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Load reads an SPDX document in the given format and returns its
+// normalized representation.
+func Load(r io.Reader, format Format) (*Document, error) {
+	switch format {
+	case FormatJSON:
+		return loadJSON(r)
+	case FormatRDF:
+		return loadRDF(r)
+	case FormatTagValue:
+		return loadTagValue(r)
+	default:
+		return nil, fmt.Errorf("sbom: unknown format %d", format)
+	}
+}
+
+// jsonDocument mirrors the subset of the SPDX 2.2/2.3 JSON schema this
+// package understands.
+type jsonDocument struct {
+	SPDXVersion       string `json:"spdxVersion"`
+	DataLicense       string `json:"dataLicense"`
+	Name              string `json:"name"`
+	DocumentNamespace string `json:"documentNamespace"`
+	Packages          []struct {
+		Name             string `json:"name"`
+		SPDXID           string `json:"SPDXID"`
+		VersionInfo      string `json:"versionInfo"`
+		DownloadLocation string `json:"downloadLocation"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		CopyrightText    string `json:"copyrightText"`
+	} `json:"packages"`
+	Files []struct {
+		FileName           string   `json:"fileName"`
+		SPDXID             string   `json:"SPDXID"`
+		LicenseInfoInFiles []string `json:"licenseInfoInFiles"`
+		LicenseConcluded   string   `json:"licenseConcluded"`
+		CopyrightText      string   `json:"copyrightText"`
+		Checksums          []struct {
+			Algorithm     string `json:"algorithm"`
+			ChecksumValue string `json:"checksumValue"`
+		} `json:"checksums"`
+	} `json:"files"`
+	Relationships []struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+		RelationshipType   string `json:"relationshipType"`
+	} `json:"relationships"`
+}
+
+func loadJSON(r io.Reader) (*Document, error) {
+	var jd jsonDocument
+	if err := json.NewDecoder(r).Decode(&jd); err != nil {
+		return nil, fmt.Errorf("sbom: decode json: %w", err)
+	}
+
+	doc := &Document{
+		SPDXVersion:       jd.SPDXVersion,
+		DataLicense:       jd.DataLicense,
+		DocumentName:      jd.Name,
+		DocumentNamespace: jd.DocumentNamespace,
+	}
+	for _, p := range jd.Packages {
+		doc.Packages = append(doc.Packages, Package{
+			Name:                    p.Name,
+			SPDXID:                  p.SPDXID,
+			VersionInfo:             p.VersionInfo,
+			DownloadLocation:        p.DownloadLocation,
+			PackageLicenseDeclared:  p.LicenseDeclared,
+			PackageLicenseConcluded: p.LicenseConcluded,
+			CopyrightText:           p.CopyrightText,
+		})
+	}
+	for _, f := range jd.Files {
+		file := File{
+			Name:              f.FileName,
+			SPDXID:            f.SPDXID,
+			LicenseInfoInFile: f.LicenseInfoInFiles,
+			LicenseConcluded:  f.LicenseConcluded,
+			CopyrightText:     f.CopyrightText,
+		}
+		for _, c := range f.Checksums {
+			if c.Algorithm == "SHA1" {
+				file.ChecksumSHA1 = c.ChecksumValue
+			}
+		}
+		doc.Files = append(doc.Files, file)
+	}
+	for _, rel := range jd.Relationships {
+		doc.Relationships = append(doc.Relationships, Relationship{
+			SPDXElementID:      rel.SPDXElementID,
+			RelatedSPDXElement: rel.RelatedSPDXElement,
+			RelationshipType:   rel.RelationshipType,
+		})
+	}
+	return doc, nil
+}
+
+// loadRDF parses the small subset of SPDX RDF/XML triples this package
+// needs, keyed on the SPDX namespace predicates. It is intentionally not a
+// general-purpose RDF/XML parser: it scans for "<spdx:Predicate ...>value
+// </spdx:Predicate>" style elements line by line, which is sufficient for
+// the documents the scanner round-trips itself and for most tool output.
+//
+// Packages and Files are tracked as the current "subject" via their
+// rdf:about identifier, and spdx:relationship blocks nested inside a
+// subject's element are recorded as a Relationship from that subject to
+// whatever spdx:relatedSpdxElement names, so DescribesFile works for
+// RDF-loaded documents the same as it does for JSON and tag-value ones.
+func loadRDF(r io.Reader) (*Document, error) {
+	doc := &Document{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	docSubject := "SPDXRef-DOCUMENT"
+	subject := docSubject
+
+	var curFile *File
+	var curPackage *Package
+	var pendingRelType string
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.Contains(line, "<spdx:SpdxDocument"):
+			if id := extractRDFAttr(line, "rdf:about"); id != "" {
+				docSubject = id
+				subject = id
+			}
+		case strings.Contains(line, "<spdx:specVersion"):
+			doc.SPDXVersion = extractRDFText(line, "spdx:specVersion")
+		case strings.Contains(line, "<spdx:name") && curFile == nil && curPackage == nil:
+			doc.DocumentName = extractRDFText(line, "spdx:name")
+
+		case strings.Contains(line, "<spdx:Package"):
+			curPackage = &Package{SPDXID: extractRDFAttr(line, "rdf:about")}
+			subject = curPackage.SPDXID
+		case curPackage != nil && curFile == nil && strings.Contains(line, "<spdx:name"):
+			curPackage.Name = extractRDFText(line, "spdx:name")
+		case curPackage != nil && strings.Contains(line, "<spdx:versionInfo"):
+			curPackage.VersionInfo = extractRDFText(line, "spdx:versionInfo")
+		case curPackage != nil && strings.Contains(line, "<spdx:downloadLocation"):
+			curPackage.DownloadLocation = extractRDFText(line, "spdx:downloadLocation")
+		case curPackage != nil && strings.Contains(line, "<spdx:licenseDeclared"):
+			curPackage.PackageLicenseDeclared = extractRDFText(line, "spdx:licenseDeclared")
+		case curPackage != nil && curFile == nil && strings.Contains(line, "<spdx:licenseConcluded"):
+			curPackage.PackageLicenseConcluded = extractRDFText(line, "spdx:licenseConcluded")
+		case curPackage != nil && curFile == nil && strings.Contains(line, "<spdx:copyrightText"):
+			curPackage.CopyrightText = extractRDFText(line, "spdx:copyrightText")
+		case strings.Contains(line, "</spdx:Package>"):
+			if curPackage != nil {
+				doc.Packages = append(doc.Packages, *curPackage)
+				curPackage = nil
+			}
+			subject = docSubject
+
+		case strings.Contains(line, "<spdx:File"):
+			curFile = &File{SPDXID: extractRDFAttr(line, "rdf:about")}
+			subject = curFile.SPDXID
+		case curFile != nil && strings.Contains(line, "<spdx:fileName"):
+			curFile.Name = extractRDFText(line, "spdx:fileName")
+		case curFile != nil && strings.Contains(line, "<spdx:licenseInfoInFile"):
+			curFile.LicenseInfoInFile = append(curFile.LicenseInfoInFile, extractRDFText(line, "spdx:licenseInfoInFile"))
+		case curFile != nil && strings.Contains(line, "<spdx:licenseConcluded"):
+			curFile.LicenseConcluded = extractRDFText(line, "spdx:licenseConcluded")
+		case curFile != nil && strings.Contains(line, "<spdx:copyrightText"):
+			curFile.CopyrightText = extractRDFText(line, "spdx:copyrightText")
+		case strings.Contains(line, "</spdx:File>"):
+			if curFile != nil {
+				doc.Files = append(doc.Files, *curFile)
+				curFile = nil
+			}
+			if curPackage != nil {
+				subject = curPackage.SPDXID
+			} else {
+				subject = docSubject
+			}
+
+		case strings.Contains(line, "<spdx:relationshipType"):
+			pendingRelType = extractRDFText(line, "spdx:relationshipType")
+		case strings.Contains(line, "<spdx:relatedSpdxElement"):
+			related := extractRDFAttr(line, "rdf:resource")
+			if related != "" && pendingRelType != "" {
+				doc.Relationships = append(doc.Relationships, Relationship{
+					SPDXElementID:      subject,
+					RelationshipType:   pendingRelType,
+					RelatedSPDXElement: related,
+				})
+			}
+			pendingRelType = ""
+		}
+	}
+	if curFile != nil {
+		doc.Files = append(doc.Files, *curFile)
+	}
+	if curPackage != nil {
+		doc.Packages = append(doc.Packages, *curPackage)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("sbom: read rdf: %w", err)
+	}
+	return doc, nil
+}
+
+// extractRDFText pulls the element text out of a single-line
+// "<prefix:Tag ...>text</prefix:Tag>" fragment. It returns "" if the line
+// has no closing tag on the same line.
+func extractRDFText(line, tag string) string {
+	open := strings.Index(line, ">")
+	close := strings.LastIndex(line, "</"+tag+">")
+	if open == -1 || close == -1 || close < open {
+		return ""
+	}
+	return strings.TrimSpace(line[open+1 : close])
+}
+
+// extractRDFAttr pulls the value of attr="..." (e.g. rdf:about, rdf:resource)
+// out of a single-line element, trimming any "#" document-fragment prefix
+// so the returned identifier matches the bare SPDXID used elsewhere.
+func extractRDFAttr(line, attr string) string {
+	needle := attr + `="`
+	start := strings.Index(line, needle)
+	if start == -1 {
+		return ""
+	}
+	start += len(needle)
+	end := strings.Index(line[start:], `"`)
+	if end == -1 {
+		return ""
+	}
+	value := line[start : start+end]
+	if idx := strings.LastIndex(value, "#"); idx != -1 {
+		value = value[idx+1:]
+	}
+	return value
+}
+
+// loadTagValue streams a classic SPDX tag-value document line by line,
+// handling multi-line "<text>...</text>" blocks for free-text fields.
+func loadTagValue(r io.Reader) (*Document, error) {
+	doc := &Document{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var curFile *File
+	var curPackage *Package
+	var inText bool
+	var textKey, textBuf string
+
+	flushFile := func() {
+		if curFile != nil {
+			doc.Files = append(doc.Files, *curFile)
+			curFile = nil
+		}
+	}
+	flushPackage := func() {
+		if curPackage != nil {
+			doc.Packages = append(doc.Packages, *curPackage)
+			curPackage = nil
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if inText {
+			if idx := strings.Index(line, "</text>"); idx >= 0 {
+				textBuf += "\n" + line[:idx]
+				applyTagValue(doc, curPackage, curFile, textKey, textBuf)
+				inText = false
+				continue
+			}
+			textBuf += "\n" + line
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if key == "FileName" {
+			flushFile()
+			curFile = &File{}
+		}
+		if key == "PackageName" {
+			flushPackage()
+			curPackage = &Package{}
+		}
+
+		if strings.HasPrefix(value, "<text>") {
+			rest := strings.TrimPrefix(value, "<text>")
+			if idx := strings.Index(rest, "</text>"); idx >= 0 {
+				applyTagValue(doc, curPackage, curFile, key, rest[:idx])
+			} else {
+				inText = true
+				textKey = key
+				textBuf = rest
+			}
+			continue
+		}
+
+		applyTagValue(doc, curPackage, curFile, key, value)
+	}
+	flushFile()
+	flushPackage()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("sbom: read tag-value: %w", err)
+	}
+	return doc, nil
+}
+
+// applyTagValue assigns a single parsed tag-value pair to whichever element
+// (document, package, or file) is currently open.
+func applyTagValue(doc *Document, pkg *Package, file *File, key, value string) {
+	switch key {
+	case "SPDXVersion":
+		doc.SPDXVersion = value
+	case "DataLicense":
+		doc.DataLicense = value
+	case "DocumentName":
+		doc.DocumentName = value
+	case "DocumentNamespace":
+		doc.DocumentNamespace = value
+	case "Relationship":
+		parts := strings.SplitN(value, " ", 3)
+		if len(parts) == 3 {
+			doc.Relationships = append(doc.Relationships, Relationship{
+				SPDXElementID:      parts[0],
+				RelationshipType:   parts[1],
+				RelatedSPDXElement: parts[2],
+			})
+		}
+	case "FileName":
+		file.Name = value
+	case "SPDXID":
+		if file != nil {
+			file.SPDXID = value
+		} else if pkg != nil {
+			pkg.SPDXID = value
+		}
+	case "LicenseInfoInFile":
+		if file != nil {
+			file.LicenseInfoInFile = append(file.LicenseInfoInFile, value)
+		}
+	case "LicenseConcluded":
+		if file != nil {
+			file.LicenseConcluded = value
+		}
+	case "PackageLicenseConcluded":
+		if pkg != nil {
+			pkg.PackageLicenseConcluded = value
+		}
+	case "FileCopyrightText":
+		if file != nil {
+			file.CopyrightText = value
+		}
+	case "PackageName":
+		pkg.Name = value
+	case "PackageVersion":
+		if pkg != nil {
+			pkg.VersionInfo = value
+		}
+	case "PackageDownloadLocation":
+		if pkg != nil {
+			pkg.DownloadLocation = value
+		}
+	case "PackageLicenseDeclared":
+		if pkg != nil {
+			pkg.PackageLicenseDeclared = value
+		}
+	case "PackageCopyrightText":
+		if pkg != nil {
+			pkg.CopyrightText = value
+		}
+	}
+}
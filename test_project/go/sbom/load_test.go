@@ -0,0 +1,156 @@
+package sbom
+
+import (
+	"strings"
+	"testing"
+)
+
+const testJSONDoc = `{
+	"spdxVersion": "SPDX-2.3",
+	"dataLicense": "CC0-1.0",
+	"name": "example",
+	"documentNamespace": "https://example.com/spdx",
+	"packages": [{
+		"name": "example-pkg",
+		"SPDXID": "SPDXRef-Package",
+		"downloadLocation": "NOASSERTION",
+		"licenseDeclared": "MIT"
+	}],
+	"files": [{
+		"fileName": "./main.go",
+		"SPDXID": "SPDXRef-File-main",
+		"licenseInfoInFiles": ["MIT"],
+		"licenseConcluded": "MIT",
+		"checksums": [{"algorithm": "SHA1", "checksumValue": "deadbeef"}]
+	}],
+	"relationships": [
+		{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package"},
+		{"spdxElementId": "SPDXRef-Package", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-File-main"}
+	]
+}`
+
+func TestLoadJSON(t *testing.T) {
+	doc, err := Load(strings.NewReader(testJSONDoc), FormatJSON)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if doc.DocumentName != "example" {
+		t.Errorf("DocumentName = %q, want %q", doc.DocumentName, "example")
+	}
+	if len(doc.Packages) != 1 || doc.Packages[0].SPDXID != "SPDXRef-Package" {
+		t.Fatalf("Packages = %+v", doc.Packages)
+	}
+	if len(doc.Files) != 1 || doc.Files[0].ChecksumSHA1 != "deadbeef" {
+		t.Fatalf("Files = %+v", doc.Files)
+	}
+	if !doc.DescribesFile("SPDXRef-File-main") {
+		t.Error("DescribesFile(SPDXRef-File-main) = false, want true (via CONTAINS from the package)")
+	}
+	if f, ok := doc.FileByName("./main.go"); !ok || f.SPDXID != "SPDXRef-File-main" {
+		t.Errorf("FileByName(./main.go) = %+v, %v", f, ok)
+	}
+}
+
+const testTagValueDoc = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+DocumentName: example
+DocumentNamespace: https://example.com/spdx
+
+PackageName: example-pkg
+SPDXID: SPDXRef-Package
+PackageDownloadLocation: NOASSERTION
+PackageLicenseDeclared: MIT
+PackageLicenseConcluded: MIT
+PackageCopyrightText: <text>Copyright 2026</text>
+
+FileName: ./main.go
+SPDXID: SPDXRef-File-main
+LicenseInfoInFile: MIT
+LicenseConcluded: MIT
+FileCopyrightText: NOASSERTION
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package
+Relationship: SPDXRef-Package CONTAINS SPDXRef-File-main
+`
+
+func TestLoadTagValue(t *testing.T) {
+	doc, err := Load(strings.NewReader(testTagValueDoc), FormatTagValue)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(doc.Packages) != 1 || doc.Packages[0].CopyrightText != "Copyright 2026" {
+		t.Fatalf("Packages = %+v", doc.Packages)
+	}
+	if doc.Packages[0].PackageLicenseConcluded != "MIT" {
+		t.Errorf("Packages[0].PackageLicenseConcluded = %q, want MIT", doc.Packages[0].PackageLicenseConcluded)
+	}
+	if len(doc.Files) != 1 || doc.Files[0].Name != "./main.go" {
+		t.Fatalf("Files = %+v", doc.Files)
+	}
+	if doc.Files[0].LicenseConcluded != "MIT" {
+		t.Errorf("Files[0].LicenseConcluded = %q, want MIT", doc.Files[0].LicenseConcluded)
+	}
+	if len(doc.Relationships) != 2 {
+		t.Fatalf("Relationships = %+v", doc.Relationships)
+	}
+	if !doc.DescribesFile("SPDXRef-File-main") {
+		t.Error("DescribesFile(SPDXRef-File-main) = false, want true")
+	}
+}
+
+const testRDFDoc = `<rdf:RDF>
+<spdx:SpdxDocument rdf:about="#SPDXRef-DOCUMENT">
+<spdx:specVersion>SPDX-2.3</spdx:specVersion>
+<spdx:name>example</spdx:name>
+<spdx:relationship>
+<spdx:Relationship>
+<spdx:relationshipType>DESCRIBES</spdx:relationshipType>
+<spdx:relatedSpdxElement rdf:resource="#SPDXRef-Package"/>
+</spdx:Relationship>
+</spdx:relationship>
+</spdx:SpdxDocument>
+<spdx:Package rdf:about="#SPDXRef-Package">
+<spdx:name>example-pkg</spdx:name>
+<spdx:downloadLocation>NOASSERTION</spdx:downloadLocation>
+<spdx:licenseDeclared>MIT</spdx:licenseDeclared>
+<spdx:relationship>
+<spdx:Relationship>
+<spdx:relationshipType>CONTAINS</spdx:relationshipType>
+<spdx:relatedSpdxElement rdf:resource="#SPDXRef-File-main"/>
+</spdx:Relationship>
+</spdx:relationship>
+</spdx:Package>
+<spdx:File rdf:about="#SPDXRef-File-main">
+<spdx:fileName>./main.go</spdx:fileName>
+<spdx:licenseInfoInFile>MIT</spdx:licenseInfoInFile>
+</spdx:File>
+</rdf:RDF>
+`
+
+func TestLoadRDF(t *testing.T) {
+	doc, err := Load(strings.NewReader(testRDFDoc), FormatRDF)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if doc.DocumentName != "example" {
+		t.Errorf("DocumentName = %q, want %q", doc.DocumentName, "example")
+	}
+	if len(doc.Packages) != 1 || doc.Packages[0].SPDXID != "SPDXRef-Package" {
+		t.Fatalf("Packages = %+v", doc.Packages)
+	}
+	if len(doc.Files) != 1 || doc.Files[0].SPDXID != "SPDXRef-File-main" {
+		t.Fatalf("Files = %+v", doc.Files)
+	}
+	if len(doc.Relationships) != 2 {
+		t.Fatalf("Relationships = %+v", doc.Relationships)
+	}
+	if !doc.DescribesFile("SPDXRef-File-main") {
+		t.Error("DescribesFile(SPDXRef-File-main) = false, want true (via CONTAINS from the package)")
+	}
+}
+
+func TestLoadUnknownFormat(t *testing.T) {
+	if _, err := Load(strings.NewReader(""), Format(99)); err == nil {
+		t.Error("Load with unknown format: want error, got nil")
+	}
+}
@@ -0,0 +1,96 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Writer emits scan results as a fresh SPDX 2.3 document.
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter returns a Writer that serializes documents to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteJSON renders doc as SPDX 2.3 JSON.
+func (wr *Writer) WriteJSON(doc *Document) error {
+	jd := jsonDocument{
+		SPDXVersion:       OrDefault(doc.SPDXVersion, "SPDX-2.3"),
+		DataLicense:       OrDefault(doc.DataLicense, "CC0-1.0"),
+		Name:              doc.DocumentName,
+		DocumentNamespace: doc.DocumentNamespace,
+	}
+	for _, p := range doc.Packages {
+		jd.Packages = append(jd.Packages, struct {
+			Name             string `json:"name"`
+			SPDXID           string `json:"SPDXID"`
+			VersionInfo      string `json:"versionInfo"`
+			DownloadLocation string `json:"downloadLocation"`
+			LicenseDeclared  string `json:"licenseDeclared"`
+			LicenseConcluded string `json:"licenseConcluded"`
+			CopyrightText    string `json:"copyrightText"`
+		}{
+			Name:             p.Name,
+			SPDXID:           p.SPDXID,
+			VersionInfo:      p.VersionInfo,
+			DownloadLocation: p.DownloadLocation,
+			LicenseDeclared:  p.PackageLicenseDeclared,
+			LicenseConcluded: p.PackageLicenseConcluded,
+			CopyrightText:    p.CopyrightText,
+		})
+	}
+	for _, f := range doc.Files {
+		entry := struct {
+			FileName           string   `json:"fileName"`
+			SPDXID             string   `json:"SPDXID"`
+			LicenseInfoInFiles []string `json:"licenseInfoInFiles"`
+			LicenseConcluded   string   `json:"licenseConcluded"`
+			CopyrightText      string   `json:"copyrightText"`
+			Checksums          []struct {
+				Algorithm     string `json:"algorithm"`
+				ChecksumValue string `json:"checksumValue"`
+			} `json:"checksums"`
+		}{
+			FileName:           f.Name,
+			SPDXID:             f.SPDXID,
+			LicenseInfoInFiles: f.LicenseInfoInFile,
+			LicenseConcluded:   f.LicenseConcluded,
+			CopyrightText:      f.CopyrightText,
+		}
+		if f.ChecksumSHA1 != "" {
+			entry.Checksums = append(entry.Checksums, struct {
+				Algorithm     string `json:"algorithm"`
+				ChecksumValue string `json:"checksumValue"`
+			}{Algorithm: "SHA1", ChecksumValue: f.ChecksumSHA1})
+		}
+		jd.Files = append(jd.Files, entry)
+	}
+	for _, rel := range doc.Relationships {
+		jd.Relationships = append(jd.Relationships, struct {
+			SPDXElementID      string `json:"spdxElementId"`
+			RelatedSPDXElement string `json:"relatedSpdxElement"`
+			RelationshipType   string `json:"relationshipType"`
+		}{
+			SPDXElementID:      rel.SPDXElementID,
+			RelatedSPDXElement: rel.RelatedSPDXElement,
+			RelationshipType:   rel.RelationshipType,
+		})
+	}
+
+	enc := json.NewEncoder(wr.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jd)
+}
+
+// OrDefault returns v, or def if v is empty. It centralizes the
+// NOASSERTION/SPDX-2.3-default fallback used when rendering SPDX fields
+// that were never populated in the normalized Document.
+func OrDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
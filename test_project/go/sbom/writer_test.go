@@ -0,0 +1,59 @@
+package sbom
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriterWriteJSON(t *testing.T) {
+	doc := &Document{
+		DocumentName: "example",
+		Packages:     []Package{{Name: "example-pkg", SPDXID: "SPDXRef-Package"}},
+		Files:        []File{{Name: "./main.go", SPDXID: "SPDXRef-File-main", ChecksumSHA1: "deadbeef"}},
+		Relationships: []Relationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Package"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).WriteJSON(doc); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	roundTripped, err := loadJSON(&buf)
+	if err != nil {
+		t.Fatalf("loadJSON(written output): %v", err)
+	}
+	if roundTripped.SPDXVersion != "SPDX-2.3" {
+		t.Errorf("SPDXVersion = %q, want default SPDX-2.3", roundTripped.SPDXVersion)
+	}
+	if roundTripped.DataLicense != "CC0-1.0" {
+		t.Errorf("DataLicense = %q, want default CC0-1.0", roundTripped.DataLicense)
+	}
+	if len(roundTripped.Files) != 1 || roundTripped.Files[0].ChecksumSHA1 != "deadbeef" {
+		t.Fatalf("Files = %+v", roundTripped.Files)
+	}
+	if len(roundTripped.Relationships) != 1 {
+		t.Fatalf("Relationships = %+v", roundTripped.Relationships)
+	}
+}
+
+func TestOrDefault(t *testing.T) {
+	if got := OrDefault("MIT", "NOASSERTION"); got != "MIT" {
+		t.Errorf("OrDefault(MIT, ...) = %q, want MIT", got)
+	}
+	if got := OrDefault("", "NOASSERTION"); got != "NOASSERTION" {
+		t.Errorf("OrDefault(\"\", NOASSERTION) = %q, want NOASSERTION", got)
+	}
+}
+
+func TestWriterWriteJSONIndented(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).WriteJSON(&Document{DocumentName: "example"}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\n  ") {
+		t.Error("WriteJSON output is not indented")
+	}
+}
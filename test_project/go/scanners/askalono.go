@@ -0,0 +1,87 @@
+package scanners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type askalonoScanner struct {
+	cfg Config
+}
+
+func newAskalonoScanner(cfg Config) *askalonoScanner {
+	return &askalonoScanner{cfg: cfg}
+}
+
+// askalonoOutput mirrors `askalono --format json identify <path>`, which
+// reports a single best match for the one file it was pointed at.
+type askalonoOutput struct {
+	License struct {
+		Name string `json:"name"`
+	} `json:"license"`
+	Score float64 `json:"score"`
+}
+
+// Scan runs askalono over root. Unlike scancode/licensee, askalono only ever
+// identifies a single file at a time, so when root is a directory Scan walks
+// it and invokes askalono once per regular file, tagging each result with
+// the file it actually ran against rather than the root. A file askalono
+// fails to identify (e.g. a binary it can't classify) is skipped rather
+// than failing the whole walk.
+func (s *askalonoScanner) Scan(ctx context.Context, root string) ([]FileLicense, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("scanners: stat %s: %w", root, err)
+	}
+	if !info.IsDir() {
+		return s.identify(ctx, root)
+	}
+
+	var results []FileLicense
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		found, err := s.identify(ctx, path)
+		if err != nil {
+			return nil
+		}
+		results = append(results, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// identify runs askalono over a single file and returns its best match, or
+// no result if askalono found nothing identifiable.
+func (s *askalonoScanner) identify(ctx context.Context, path string) ([]FileLicense, error) {
+	out, err := runJSON(ctx, s.cfg, "askalono", "--format", "json", "identify", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed askalonoOutput
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return nil, fmt.Errorf("scanners: parse askalono output: %w", err)
+	}
+	if parsed.License.Name == "" {
+		return nil, nil
+	}
+
+	return []FileLicense{{
+		Path:           path,
+		SPDXExpression: parsed.License.Name,
+		Score:          parsed.Score,
+		Detector:       "askalono",
+	}}, nil
+}
@@ -0,0 +1,93 @@
+package scanners
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+)
+
+// writeFakeAskalono writes a shell script that mimics `askalono --format
+// json identify <path>` by always reporting the same MIT match, regardless
+// of which file it was pointed at. It is placed outside the source tree
+// being scanned so the walk in TestAskalonoScannerScanWalksDirectoryPerFile
+// doesn't pick it up.
+func writeFakeAskalono(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake askalono script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "fake-askalono.sh")
+	body := "#!/bin/sh\necho '{\"license\":{\"name\":\"MIT\"},\"score\":0.92}'\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("write fake askalono script: %v", err)
+	}
+	return script
+}
+
+func TestAskalonoScannerScanWalksDirectoryPerFile(t *testing.T) {
+	script := writeFakeAskalono(t)
+
+	root := t.TempDir()
+	files := []string{"a.go", filepath.Join("sub", "b.go")}
+	for _, name := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("package x"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	s := newAskalonoScanner(Config{BinaryPath: script})
+	results, err := s.Scan(context.Background(), root)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(results) != len(files) {
+		t.Fatalf("Scan() returned %d results, want %d: %+v", len(results), len(files), results)
+	}
+
+	var gotPaths []string
+	for _, r := range results {
+		if r.SPDXExpression != "MIT" {
+			t.Errorf("result %+v: SPDXExpression = %q, want MIT", r, r.SPDXExpression)
+		}
+		if r.Path == root {
+			t.Errorf("result %+v: Path is the scan root, want the individual file", r)
+		}
+		gotPaths = append(gotPaths, r.Path)
+	}
+	sort.Strings(gotPaths)
+
+	wantPaths := []string{filepath.Join(root, "a.go"), filepath.Join(root, "sub", "b.go")}
+	sort.Strings(wantPaths)
+	for i := range wantPaths {
+		if gotPaths[i] != wantPaths[i] {
+			t.Errorf("gotPaths[%d] = %q, want %q", i, gotPaths[i], wantPaths[i])
+		}
+	}
+}
+
+func TestAskalonoScannerScanSingleFile(t *testing.T) {
+	script := writeFakeAskalono(t)
+
+	root := t.TempDir()
+	path := filepath.Join(root, "a.go")
+	if err := os.WriteFile(path, []byte("package x"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+
+	s := newAskalonoScanner(Config{BinaryPath: script})
+	results, err := s.Scan(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(results) != 1 || results[0].Path != path {
+		t.Fatalf("Scan(%q) = %+v, want a single result for that path", path, results)
+	}
+}
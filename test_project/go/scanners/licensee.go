@@ -0,0 +1,56 @@
+package scanners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type licenseeScanner struct {
+	cfg Config
+}
+
+func newLicenseeScanner(cfg Config) *licenseeScanner {
+	return &licenseeScanner{cfg: cfg}
+}
+
+// licenseeOutput mirrors the subset of `licensee detect --json` output
+// this package consumes. Licensee reports per-matched-file confidence
+// against the single license it identified for the whole project, so each
+// entry in Matched Files becomes one FileLicense.
+type licenseeOutput struct {
+	MatchedFiles []struct {
+		Filename string `json:"filename"`
+		Matcher  struct {
+			Confidence float64 `json:"confidence"`
+		} `json:"matcher"`
+	} `json:"matched_files"`
+	License struct {
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+}
+
+// Scan runs licensee over path and returns the project-level license it
+// detects, attributed to every file licensee matched against it.
+func (s *licenseeScanner) Scan(ctx context.Context, path string) ([]FileLicense, error) {
+	out, err := runJSON(ctx, s.cfg, "licensee", "detect", "--json", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed licenseeOutput
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return nil, fmt.Errorf("scanners: parse licensee output: %w", err)
+	}
+
+	var results []FileLicense
+	for _, f := range parsed.MatchedFiles {
+		results = append(results, FileLicense{
+			Path:           f.Filename,
+			SPDXExpression: parsed.License.SPDXID,
+			Score:          f.Matcher.Confidence / 100,
+			Detector:       "licensee",
+		})
+	}
+	return results, nil
+}
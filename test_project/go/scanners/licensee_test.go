@@ -0,0 +1,42 @@
+package scanners
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLicenseeScannerScan(t *testing.T) {
+	script := writeFakeJSONTool(t, `{
+		"matched_files": [
+			{"filename": "LICENSE", "matcher": {"confidence": 100}},
+			{"filename": "README.md", "matcher": {"confidence": 87.5}}
+		],
+		"license": {"spdx_id": "MIT"}
+	}`)
+
+	s := newLicenseeScanner(Config{BinaryPath: script})
+	results, err := s.Scan(context.Background(), "root")
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Scan() = %+v, want 2 results", results)
+	}
+	for i, want := range []struct {
+		path  string
+		score float64
+	}{
+		{"LICENSE", 1.0},
+		{"README.md", 0.875},
+	} {
+		if results[i].Path != want.path {
+			t.Errorf("results[%d].Path = %q, want %q", i, results[i].Path, want.path)
+		}
+		if results[i].SPDXExpression != "MIT" {
+			t.Errorf("results[%d].SPDXExpression = %q, want MIT", i, results[i].SPDXExpression)
+		}
+		if results[i].Score != want.score {
+			t.Errorf("results[%d].Score = %v, want %v", i, results[i].Score, want.score)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package scanners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type scancodeScanner struct {
+	cfg Config
+}
+
+func newScancodeScanner(cfg Config) *scancodeScanner {
+	return &scancodeScanner{cfg: cfg}
+}
+
+// scancodeOutput mirrors the subset of `scancode --license --json-pp -`
+// output this package consumes.
+type scancodeOutput struct {
+	Files []struct {
+		Path              string `json:"path"`
+		Type              string `json:"type"`
+		LicenseDetections []struct {
+			LicenseExpressionSPDX string  `json:"license_expression_spdx"`
+			Score                 float64 `json:"score"`
+		} `json:"license_detections"`
+	} `json:"files"`
+}
+
+// Scan runs scancode over path and returns its highest-confidence license
+// match per file.
+func (s *scancodeScanner) Scan(ctx context.Context, path string) ([]FileLicense, error) {
+	out, err := runJSON(ctx, s.cfg, "scancode", "--license", "--json-pp", "-", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed scancodeOutput
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return nil, fmt.Errorf("scanners: parse scancode output: %w", err)
+	}
+
+	var results []FileLicense
+	for _, f := range parsed.Files {
+		if f.Type != "file" || len(f.LicenseDetections) == 0 {
+			continue
+		}
+		best := f.LicenseDetections[0]
+		for _, d := range f.LicenseDetections[1:] {
+			if d.Score > best.Score {
+				best = d
+			}
+		}
+		results = append(results, FileLicense{
+			Path:           f.Path,
+			SPDXExpression: best.LicenseExpressionSPDX,
+			Score:          best.Score / 100, // scancode reports a 0-100 percentage
+			Detector:       "scancode",
+		})
+	}
+	return results, nil
+}
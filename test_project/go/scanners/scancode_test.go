@@ -0,0 +1,59 @@
+package scanners
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// writeFakeJSONTool writes a shell script that ignores its arguments and
+// echoes body to stdout, mimicking an external detector's CLI for tests
+// that only need to exercise this package's own output parsing.
+func writeFakeJSONTool(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tool script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "fake-tool.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat <<'JSON'\n"+body+"\nJSON\n"), 0o755); err != nil {
+		t.Fatalf("write fake tool script: %v", err)
+	}
+	return script
+}
+
+func TestScancodeScannerScan(t *testing.T) {
+	script := writeFakeJSONTool(t, `{
+		"files": [
+			{
+				"path": "a.go",
+				"type": "file",
+				"license_detections": [
+					{"license_expression_spdx": "Apache-2.0", "score": 62.5},
+					{"license_expression_spdx": "MIT", "score": 90.0}
+				]
+			},
+			{"path": "somedir", "type": "directory"},
+			{"path": "b.go", "type": "file", "license_detections": []}
+		]
+	}`)
+
+	s := newScancodeScanner(Config{BinaryPath: script})
+	results, err := s.Scan(context.Background(), "root")
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Scan() = %+v, want exactly 1 result (directories and files with no detections are skipped)", results)
+	}
+
+	got := results[0]
+	if got.SPDXExpression != "MIT" {
+		t.Errorf("SPDXExpression = %q, want the highest-scoring detection MIT", got.SPDXExpression)
+	}
+	if got.Score != 0.9 {
+		t.Errorf("Score = %v, want 0.9 (scancode's 0-100 score normalized to 0-1)", got.Score)
+	}
+}
@@ -0,0 +1,103 @@
+// Package scanners integrates third-party license detectors (scancode,
+// licensee, askalono) as pluggable external Scanners, so results from the
+// in-source SPDX-License-Identifier header can be cross-checked against
+// what a detector sees in the file body.
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// FileLicense is one file's license as reported by an external detector.
+type FileLicense struct {
+	Path           string
+	SPDXExpression string
+	Score          float64 // detector confidence, 0-1
+	Detector       string  // e.g. "scancode", "licensee", "askalono"
+}
+
+// Scanner runs an external license detector over path and returns its
+// per-file findings.
+type Scanner interface {
+	Scan(ctx context.Context, path string) ([]FileLicense, error)
+}
+
+// Config controls how an external Scanner's binary is located and bounded.
+type Config struct {
+	// BinaryPath overrides the detector's default name on $PATH.
+	BinaryPath string
+	// Timeout bounds each run of the detector binary. A Scan that runs the
+	// binary more than once (askalono over a directory) gives every run its
+	// own Timeout. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// New returns the built-in Scanner registered under name ("scancode",
+// "licensee", or "askalono").
+func New(name string, cfg Config) (Scanner, error) {
+	switch name {
+	case "scancode":
+		return newScancodeScanner(cfg), nil
+	case "licensee":
+		return newLicenseeScanner(cfg), nil
+	case "askalono":
+		return newAskalonoScanner(cfg), nil
+	case "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("scanners: unknown external scanner %q", name)
+	}
+}
+
+// runJSON executes bin with args (bounded by cfg.Timeout) and returns its
+// stdout for the caller to decode.
+func runJSON(ctx context.Context, cfg Config, bin string, args ...string) ([]byte, error) {
+	if cfg.BinaryPath != "" {
+		bin = cfg.BinaryPath
+	}
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, bin, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("scanners: %s: %w: %s", bin, err, exitErr.Stderr)
+		}
+		return nil, fmt.Errorf("scanners: %s: %w", bin, err)
+	}
+	return out, nil
+}
+
+// Agreement describes whether an external detector's finding for a file
+// agrees with the in-source SPDX-License-Identifier header.
+type Agreement struct {
+	Path     string
+	InSource string
+	Detected FileLicense
+	Agrees   bool
+}
+
+// Merge cross-checks each external finding against the in-source SPDX
+// headers (keyed by file path) and reports whether they agree.
+func Merge(inSource map[string]string, detected []FileLicense) []Agreement {
+	agreements := make([]Agreement, 0, len(detected))
+	for _, d := range detected {
+		header, ok := inSource[d.Path]
+		if !ok {
+			continue
+		}
+		agreements = append(agreements, Agreement{
+			Path:     d.Path,
+			InSource: header,
+			Detected: d,
+			Agrees:   header == d.SPDXExpression,
+		})
+	}
+	return agreements
+}
@@ -0,0 +1,42 @@
+package scanners
+
+import "testing"
+
+func TestNewUnknownScanner(t *testing.T) {
+	if _, err := New("not-a-real-scanner", Config{}); err == nil {
+		t.Error("New with an unknown name: want error, got nil")
+	}
+}
+
+func TestNewNoScanner(t *testing.T) {
+	s, err := New("", Config{})
+	if err != nil {
+		t.Fatalf("New(\"\"): %v", err)
+	}
+	if s != nil {
+		t.Errorf("New(\"\") = %v, want nil", s)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	inSource := map[string]string{
+		"a.go": "MIT",
+		"b.go": "Apache-2.0",
+	}
+	detected := []FileLicense{
+		{Path: "a.go", SPDXExpression: "MIT", Detector: "scancode"},
+		{Path: "b.go", SPDXExpression: "MIT", Detector: "scancode"},
+		{Path: "c.go", SPDXExpression: "MIT", Detector: "scancode"}, // no in-source header, ignored
+	}
+
+	got := Merge(inSource, detected)
+	if len(got) != 2 {
+		t.Fatalf("Merge() = %+v, want 2 agreements", got)
+	}
+	if !got[0].Agrees {
+		t.Errorf("agreement for a.go: Agrees = false, want true")
+	}
+	if got[1].Agrees {
+		t.Errorf("agreement for b.go: Agrees = true, want false (Apache-2.0 != MIT)")
+	}
+}
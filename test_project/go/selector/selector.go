@@ -0,0 +1,130 @@
+// Package selector implements a small include/exclude path matcher driven
+// by space-separated glob patterns, with "!" negation and last-match-wins
+// semantics — similar in spirit to a .gitignore line, but evaluated against
+// a single pattern string rather than a file. Put broad excludes first and
+// narrower re-includes after them, since a later pattern overrides an
+// earlier one that also matched.
+package selector
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Matcher decides whether a given path matches a parsed set of patterns.
+type Matcher interface {
+	// Match reports whether path matches the pattern set. The last pattern
+	// in the string that matches path wins, so a later "!foo/*" can
+	// re-include something an earlier "foo/*" excluded.
+	Match(path string) bool
+}
+
+// rule is a single compiled pattern, optionally negated.
+type rule struct {
+	pattern  string
+	negate   bool
+	segments []string // pattern split on "/", used by dir-prefix and "**" matching
+}
+
+type matcher struct {
+	rules []rule
+}
+
+// Parse compiles a space-separated pattern string into a Matcher. Patterns
+// are filepath.Match globs, with two directory-aware extensions:
+//
+//   - a pattern ending in "/*" matches that directory and everything under
+//     it at any depth (like a .gitignore "dir/" entry), not just its direct
+//     children — filepath.Match alone never crosses a "/".
+//   - "**" in a pattern matches zero or more path segments.
+//
+// Prefixing a pattern with "!" negates it. Patterns are evaluated in order
+// and the last one to match wins, so put broad excludes first and narrower
+// re-includes after them, e.g.:
+//
+//	"vendor/* third_party/* !third_party/ourfork/*"
+func Parse(patterns string) (Matcher, error) {
+	fields := strings.Fields(patterns)
+	m := &matcher{rules: make([]rule, 0, len(fields))}
+	for _, field := range fields {
+		r := rule{pattern: field}
+		if strings.HasPrefix(field, "!") {
+			r.negate = true
+			r.pattern = strings.TrimPrefix(field, "!")
+		}
+		if r.pattern == "" {
+			return nil, fmt.Errorf("selector: empty pattern in %q", patterns)
+		}
+		// filepath.Match validates the whole pattern's glob syntax (bracket
+		// expressions, escapes, ...) regardless of what it's matched
+		// against, so this also catches errors in patterns using the "/*"
+		// or "**" extensions handled separately in rule.matches.
+		if _, err := filepath.Match(r.pattern, "probe"); err != nil {
+			return nil, fmt.Errorf("selector: invalid pattern %q: %w", r.pattern, err)
+		}
+		r.segments = strings.Split(r.pattern, "/")
+		m.rules = append(m.rules, r)
+	}
+	return m, nil
+}
+
+// Match implements Matcher.
+func (m *matcher) Match(path string) bool {
+	matched := false
+	for _, r := range m.rules {
+		if !r.matches(path) {
+			continue
+		}
+		matched = !r.negate
+	}
+	return matched
+}
+
+// matches reports whether path satisfies r.pattern, honoring the "/*"
+// directory-prefix and "**" multi-segment extensions documented on Parse.
+func (r rule) matches(path string) bool {
+	if prefix, ok := strings.CutSuffix(r.pattern, "/*"); ok {
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+	if strings.Contains(r.pattern, "**") {
+		return matchSegments(r.segments, strings.Split(path, "/"))
+	}
+	return mustMatch(r.pattern, path)
+}
+
+// mustMatch wraps filepath.Match for patterns Parse has already validated,
+// so a syntax error here means validation has a bug rather than a real
+// invalid pattern having reached this code path.
+func mustMatch(pattern, path string) bool {
+	ok, err := filepath.Match(pattern, path)
+	if err != nil {
+		panic(fmt.Sprintf("selector: pattern %q passed Parse's validation but failed to match: %v", pattern, err))
+	}
+	return ok
+}
+
+// matchSegments matches path segments against pattern segments one at a
+// time, treating "**" as matching zero or more path segments and any other
+// segment as a single-segment filepath.Match glob.
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		if matchSegments(pattern[1:], path) {
+			return true
+		}
+		if len(path) == 0 {
+			return false
+		}
+		return matchSegments(pattern, path[1:])
+	}
+	if len(path) == 0 {
+		return false
+	}
+	if !mustMatch(pattern[0], path[0]) {
+		return false
+	}
+	return matchSegments(pattern[1:], path[1:])
+}
@@ -0,0 +1,65 @@
+package selector
+
+import "testing"
+
+func TestParseInvalidPattern(t *testing.T) {
+	if _, err := Parse("vendor/* !"); err == nil {
+		t.Error("Parse with an empty negated pattern: want error, got nil")
+	}
+	if _, err := Parse("[invalid"); err == nil {
+		t.Error("Parse with a malformed glob: want error, got nil")
+	}
+	if _, err := Parse("vendor/[bad/sub.go"); err == nil {
+		t.Error("Parse with an unterminated bracket in a non-final segment: want error, got nil")
+	}
+}
+
+func TestMatcherMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns string
+		path     string
+		want     bool
+	}{
+		{"no patterns", "", "main.go", false},
+		{"direct glob match", "*.go", "main.go", true},
+		{"direct glob miss", "*.go", "main.rs", false},
+		{"dir pattern matches direct child", "vendor/*", "vendor/lib.go", true},
+		{"dir pattern matches nested child", "vendor/*", "vendor/foo/bar.go", true},
+		{"dir pattern does not match sibling", "vendor/*", "third_party/lib.go", false},
+		{"double-star matches nested", "third_party/**/*.go", "third_party/foo/bar/baz.go", true},
+		{
+			// Match() on an exclude pattern set means "this path is excluded";
+			// the trailing "!third_party/ourfork/*" fires last and flips it
+			// back to not-excluded.
+			name:     "last-match-wins re-include overrides earlier exclude",
+			patterns: "vendor/* third_party/* !third_party/ourfork/*",
+			path:     "third_party/ourfork/main.go",
+			want:     false,
+		},
+		{
+			name:     "last-match-wins still excludes outside the re-include",
+			patterns: "vendor/* third_party/* !third_party/ourfork/*",
+			path:     "third_party/upstream/main.go",
+			want:     true,
+		},
+		{
+			name:     "earlier re-include loses to a later broader exclude",
+			patterns: "!third_party/ourfork/* third_party/*",
+			path:     "third_party/ourfork/main.go",
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Parse(tt.patterns)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.patterns, err)
+			}
+			if got := m.Match(tt.path); got != tt.want {
+				t.Errorf("Match(%q) with patterns %q = %v, want %v", tt.path, tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"valid with database", Config{Port: 8080, DatabaseURL: "postgres://localhost/db"}, false},
+		{"valid debug without database", Config{Port: 8080, Debug: true}, false},
+		{"non-positive port", Config{Port: 0, Debug: true}, true},
+		{"missing database outside debug", Config{Port: 8080}, true},
+		{"invalid include pattern", Config{Port: 8080, Debug: true, Include: "[bad"}, true},
+		{"invalid exclude pattern", Config{Port: 8080, Debug: true, Exclude: "[bad"}, true},
+		{"valid include/exclude", Config{Port: 8080, Debug: true, Include: "*.go", Exclude: "vendor/*"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig(%+v) = %v, wantErr %v", tt.config, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// startTestServer brings up s on a loopback listener chosen by the OS and
+// returns the base URL to reach it. It registers a t.Cleanup that shuts the
+// server down.
+func startTestServer(t *testing.T, s *Server) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go s.Serve(ln)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := s.Shutdown(ctx); err != nil {
+			t.Errorf("Shutdown: %v", err)
+		}
+	})
+	return "http://" + ln.Addr().String()
+}
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	logger := log.New(io.Discard, "", 0)
+	s, err := NewServer(Config{Port: 8080, Debug: true}, logger)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	return s
+}
+
+func TestServerHealthz(t *testing.T) {
+	base := startTestServer(t, newTestServer(t))
+
+	resp, err := http.Get(base + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "ok" {
+		t.Errorf("body = %q, want \"ok\"", body)
+	}
+}
+
+// scanAndFetchReport drives a full /scan against dir, waits for the
+// streamed NDJSON response to finish, and returns the *ScanReport the
+// server stored for it by locating it directly in s.reports.
+func scanAndFetchReport(t *testing.T, s *Server, base, dir string) *ScanReport {
+	t.Helper()
+	reqBody, _ := json.Marshal(scanRequest{Path: dir, Recursive: true})
+	resp, err := http.Post(base+"/scan", "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		t.Fatalf("POST /scan: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("POST /scan status = %d, body = %s", resp.StatusCode, body)
+	}
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		t.Fatalf("reading /scan response: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, report := range s.reports {
+		if report.Path == dir {
+			return report
+		}
+	}
+	t.Fatalf("no stored report found for path %q", dir)
+	return nil
+}
+
+func TestServerScanAndReport(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "licensed.go"), []byte("// SPDX-License-Identifier: MIT\npackage x\n"), 0o644); err != nil {
+		t.Fatalf("write licensed.go: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "unlicensed.go"), []byte("package x\n"), 0o644); err != nil {
+		t.Fatalf("write unlicensed.go: %v", err)
+	}
+
+	s := newTestServer(t)
+	base := startTestServer(t, s)
+
+	report := scanAndFetchReport(t, s, base, dir)
+	if len(report.Results) != 2 {
+		t.Fatalf("report.Results = %+v, want 2 entries", report.Results)
+	}
+
+	var sawLicensed, sawMissing bool
+	for _, r := range report.Results {
+		switch filepath.Base(r.File) {
+		case "licensed.go":
+			sawLicensed = true
+			if !r.Valid || r.SPDXID != "MIT" {
+				t.Errorf("licensed.go result = %+v, want Valid=true SPDXID=MIT", r)
+			}
+		case "unlicensed.go":
+			sawMissing = true
+			if r.Valid || r.Reason != "missing-header" {
+				t.Errorf("unlicensed.go result = %+v, want Valid=false Reason=missing-header", r)
+			}
+		}
+	}
+	if !sawLicensed || !sawMissing {
+		t.Fatalf("report.Results did not cover both files: %+v", report.Results)
+	}
+
+	t.Run("format=spdx-json", func(t *testing.T) {
+		resp, err := http.Get(base + "/report/" + report.ID + "?format=spdx-json")
+		if err != nil {
+			t.Fatalf("GET /report: %v", err)
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		var doc struct {
+			SPDXVersion string `json:"spdxVersion"`
+			Packages    []struct {
+				Name string `json:"name"`
+			} `json:"packages"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+			t.Fatalf("decode spdx-json response: %v", err)
+		}
+		if doc.SPDXVersion != "SPDX-2.3" {
+			t.Errorf("SPDXVersion = %q, want SPDX-2.3", doc.SPDXVersion)
+		}
+		if len(doc.Packages) != 1 {
+			t.Fatalf("Packages = %+v, want 1 entry", doc.Packages)
+		}
+	})
+
+	t.Run("format=spdx-tv", func(t *testing.T) {
+		resp, err := http.Get(base + "/report/" + report.ID + "?format=spdx-tv")
+		if err != nil {
+			t.Fatalf("GET /report: %v", err)
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("Content-Type = %q, want text/plain", ct)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("read spdx-tv response: %v", err)
+		}
+		out := string(body)
+		for _, want := range []string{"SPDXVersion: SPDX-2.3", "PackageName: " + dir, "Relationship: SPDXRef-DOCUMENT DESCRIBES"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("spdx-tv output missing %q\ngot:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("unknown id", func(t *testing.T) {
+		resp, err := http.Get(base + "/report/does-not-exist")
+		if err != nil {
+			t.Fatalf("GET /report: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("status = %d, want 404", resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Package tvsaver renders scan results as a valid SPDX 2.3 tag-value
+// document, streaming directly to an io.Writer so the whole SBOM never
+// needs to be buffered in memory.
+package tvsaver
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eric2023/spdx-checker/sbom"
+)
+
+// Writer renders an SPDX 2.3 tag-value document section by section.
+type Writer struct {
+	w   io.Writer
+	err error
+}
+
+// NewWriter returns a Writer that streams its output to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write renders doc in full: document info, packages (with a computed
+// PackageVerificationCode), files, and relationships. renderReview is
+// available for callers that also need to emit a Review section, but
+// Write does not call it itself since Document does not model reviews.
+func (tw *Writer) Write(doc *sbom.Document) error {
+	tw.renderDocumentInfo(doc)
+	for _, pkg := range doc.Packages {
+		tw.renderPackage(pkg, doc.Files)
+	}
+	for _, file := range doc.Files {
+		tw.renderFile(file)
+	}
+	for _, rel := range doc.Relationships {
+		tw.renderRelationship(rel)
+	}
+	return tw.err
+}
+
+// renderDocumentInfo emits the document's creation-info section, including
+// the Creator/Created tags SPDX 2.3 requires on every document.
+func (tw *Writer) renderDocumentInfo(doc *sbom.Document) {
+	tw.writeKV("SPDXVersion", sbom.OrDefault(doc.SPDXVersion, "SPDX-2.3"))
+	tw.writeKV("DataLicense", sbom.OrDefault(doc.DataLicense, "CC0-1.0"))
+	tw.writeKV("SPDXID", "SPDXRef-DOCUMENT")
+	tw.writeKV("DocumentName", doc.DocumentName)
+	tw.writeKV("DocumentNamespace", doc.DocumentNamespace)
+	tw.writeKV("Creator", "Tool: spdx-checker")
+	tw.writeKV("Created", time.Now().UTC().Format(time.RFC3339))
+	tw.blankLine()
+}
+
+// renderPackage emits a Package section, computing its
+// PackageVerificationCode from files as the SHA-1 of the concatenation of
+// every other file's SHA-1, sorted ascending, excluding the SPDX document
+// file itself.
+func (tw *Writer) renderPackage(pkg sbom.Package, files []sbom.File) {
+	tw.writeKV("PackageName", pkg.Name)
+	tw.writeKV("SPDXID", pkg.SPDXID)
+	tw.writeKV("PackageVersion", pkg.VersionInfo)
+	tw.writeKV("PackageDownloadLocation", sbom.OrDefault(pkg.DownloadLocation, "NOASSERTION"))
+	tw.writeKV("PackageLicenseDeclared", sbom.OrDefault(pkg.PackageLicenseDeclared, "NOASSERTION"))
+	tw.writeKV("PackageLicenseConcluded", sbom.OrDefault(pkg.PackageLicenseConcluded, "NOASSERTION"))
+	tw.writeTextKV("PackageCopyrightText", sbom.OrDefault(pkg.CopyrightText, "NOASSERTION"))
+	if code := packageVerificationCode(files); code != "" {
+		tw.writeKV("PackageVerificationCode", code)
+	}
+	tw.blankLine()
+}
+
+// renderFile emits a File section.
+func (tw *Writer) renderFile(file sbom.File) {
+	tw.writeKV("FileName", file.Name)
+	tw.writeKV("SPDXID", file.SPDXID)
+	for _, license := range file.LicenseInfoInFile {
+		tw.writeKV("LicenseInfoInFile", license)
+	}
+	tw.writeKV("LicenseConcluded", sbom.OrDefault(file.LicenseConcluded, "NOASSERTION"))
+	tw.writeTextKV("FileCopyrightText", sbom.OrDefault(file.CopyrightText, "NOASSERTION"))
+	if file.ChecksumSHA1 != "" {
+		tw.writeKV("FileChecksum", "SHA1: "+file.ChecksumSHA1)
+	}
+	tw.blankLine()
+}
+
+// renderRelationship emits a single Relationship line.
+func (tw *Writer) renderRelationship(rel sbom.Relationship) {
+	tw.writeKV("Relationship", fmt.Sprintf("%s %s %s", rel.SPDXElementID, rel.RelationshipType, rel.RelatedSPDXElement))
+}
+
+// renderReview emits an SPDX Review section for a reviewer comment
+// (Reviews were removed from the spec after 2.2 in favor of annotations,
+// but some consumers still expect them when present).
+func (tw *Writer) renderReview(reviewer, comment string) {
+	tw.writeKV("Reviewer", reviewer)
+	tw.writeTextKV("ReviewComment", comment)
+	tw.blankLine()
+}
+
+func (tw *Writer) writeKV(key, value string) {
+	if tw.err != nil || value == "" {
+		return
+	}
+	_, tw.err = fmt.Fprintf(tw.w, "%s: %s\n", key, value)
+}
+
+// writeTextKV emits a free-text field, wrapping it in <text>...</text> via
+// textify whenever the value spans multiple lines or itself contains a
+// "</text>" sequence that would otherwise terminate the block early.
+func (tw *Writer) writeTextKV(key, value string) {
+	if tw.err != nil || value == "" {
+		return
+	}
+	if strings.Contains(value, "\n") || strings.Contains(value, "</text>") {
+		_, tw.err = fmt.Fprintf(tw.w, "%s: %s\n", key, textify(value))
+		return
+	}
+	_, tw.err = fmt.Fprintf(tw.w, "%s: %s\n", key, value)
+}
+
+func (tw *Writer) blankLine() {
+	if tw.err != nil {
+		return
+	}
+	_, tw.err = fmt.Fprint(tw.w, "\n")
+}
+
+// textify wraps s in an SPDX <text>...</text> block, escaping any embedded
+// "</text>" so it does not prematurely close the block.
+func textify(s string) string {
+	escaped := strings.ReplaceAll(s, "</text>", `<\/text>`)
+	return "<text>" + escaped + "</text>"
+}
+
+// packageVerificationCode computes the SPDX PackageVerificationCode: the
+// SHA-1 of the concatenation of every file's SHA-1 checksum, sorted
+// ascending by hex value, excluding the SPDX document file itself.
+func packageVerificationCode(files []sbom.File) string {
+	shas := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.ChecksumSHA1 == "" || strings.HasSuffix(f.Name, ".spdx") {
+			continue
+		}
+		shas = append(shas, f.ChecksumSHA1)
+	}
+	if len(shas) == 0 {
+		return ""
+	}
+	sort.Strings(shas)
+
+	h := sha1.New()
+	for _, s := range shas {
+		h.Write([]byte(s))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,86 @@
+package tvsaver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/eric2023/spdx-checker/sbom"
+)
+
+func TestWriterWrite(t *testing.T) {
+	doc := &sbom.Document{
+		DocumentName:      "example",
+		DocumentNamespace: "https://example.com/spdx",
+		Packages: []sbom.Package{{
+			Name:   "example-pkg",
+			SPDXID: "SPDXRef-Package",
+		}},
+		Files: []sbom.File{
+			{Name: "./a.go", SPDXID: "SPDXRef-File-a", LicenseInfoInFile: []string{"MIT"}, ChecksumSHA1: "aaaa"},
+			{Name: "./b.go", SPDXID: "SPDXRef-File-b", LicenseInfoInFile: []string{"MIT"}, ChecksumSHA1: "bbbb"},
+		},
+		Relationships: []sbom.Relationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Package"},
+			{SPDXElementID: "SPDXRef-Package", RelationshipType: "CONTAINS", RelatedSPDXElement: "SPDXRef-File-a"},
+			{SPDXElementID: "SPDXRef-Package", RelationshipType: "CONTAINS", RelatedSPDXElement: "SPDXRef-File-b"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"SPDXVersion: SPDX-2.3",
+		"DataLicense: CC0-1.0",
+		"DocumentName: example",
+		"Creator: Tool: spdx-checker",
+		"PackageName: example-pkg",
+		"PackageDownloadLocation: NOASSERTION",
+		"FileName: ./a.go",
+		"LicenseInfoInFile: MIT",
+		"Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package",
+		"Relationship: SPDXRef-Package CONTAINS SPDXRef-File-b",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\ngot:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(out, "Created: ") {
+		t.Error("output missing a Created tag")
+	}
+	if !strings.Contains(out, "PackageVerificationCode: ") {
+		t.Error("output missing PackageVerificationCode computed from the files' checksums")
+	}
+}
+
+func TestWriterWriteEmptyDocument(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(&sbom.Document{}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if strings.Contains(buf.String(), "PackageVerificationCode") {
+		t.Error("output has a PackageVerificationCode with no files present")
+	}
+}
+
+func TestWriterWriteTextFieldWithEmbeddedCloseTag(t *testing.T) {
+	doc := &sbom.Document{
+		Packages: []sbom.Package{{
+			Name:          "example-pkg",
+			SPDXID:        "SPDXRef-Package",
+			CopyrightText: "Contains a literal </text> sequence",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(doc); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<\/text>`) {
+		t.Errorf("embedded \"</text>\" was not escaped:\n%s", buf.String())
+	}
+}